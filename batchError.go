@@ -0,0 +1,32 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "fmt"
+
+// BatchErrorRow holds the Oracle error reported for a single row of a
+// Stmt.ExecBatch call.
+type BatchErrorRow struct {
+	// RowIndex is the zero-based index, within the full rowCount passed to
+	// ExecBatch, of the row the error applies to.
+	RowIndex int
+
+	// ORAError is the Oracle error reported for the row.
+	ORAError *ORAError
+}
+
+// BatchError is returned by Stmt.ExecBatch when one or more rows of an
+// array DML execute failed under Oracle's OCI_BATCH_ERRORS mode, so a
+// caller can recover per-row diagnostics instead of losing them behind a
+// single aggregate error.
+type BatchError struct {
+	// Rows holds one entry per row that failed.
+	Rows []BatchErrorRow
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("ora: %d row(s) failed in batch execute", len(e.Rows))
+}
@@ -0,0 +1,20 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// BindDirection indicates whether a bound parameter passes a value into a
+// SQL statement, receives a value out of it, or both.
+type BindDirection uint8
+
+const (
+	// In binds a parameter as input-only.
+	In BindDirection = iota
+
+	// Out binds a parameter as output-only.
+	Out
+
+	// InOut binds a parameter as both input and output.
+	InOut
+)
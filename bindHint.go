@@ -0,0 +1,37 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// BindHint specifies how a single parameter position of a Stmt is bound,
+// independent of the other positions in the same statement — e.g. mixing a
+// []byte bound as Bits (RAW/BLOB) at one position with a []byte bound as U8
+// (NUMBER) at another, which a single statement-wide StmtConfig.ByteSlice
+// cannot express.
+type BindHint struct {
+	// GctType is the GoColumnType the bind buffer is interpreted as.
+	//
+	// The zero value defers to StmtConfig.ByteSlice for []byte parameters.
+	GctType GoColumnType
+
+	// MaxSize is the maximum size in bytes of the bind buffer.
+	//
+	// The zero value defers to StmtConfig.StringPtrBufferSize for string
+	// parameters.
+	MaxSize int
+
+	// Direction indicates whether the parameter is In, Out, or InOut.
+	//
+	// Out and InOut grow the bind buffer to MaxSize even when the supplied
+	// value is shorter, so the driver has room to write a returned value
+	// back into it.
+	//
+	// The default is In.
+	Direction BindDirection
+
+	// ZeroCopy indicates the bind buffer is caller-owned: Stmt.ExecHints
+	// binds directly into the []byte value passed for this position instead
+	// of allocating and copying to a buffer sized by MaxSize.
+	ZeroCopy bool
+}
@@ -0,0 +1,19 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// FetchMode indicates whether a SQL select statement is executed for
+// forward-only or scrollable row access.
+type FetchMode uint8
+
+const (
+	// FetchForward executes a statement for forward-only row access.
+	FetchForward FetchMode = iota
+
+	// FetchScrollable executes a statement with OCI_STMT_SCROLLABLE_READONLY,
+	// unlocking Rset's navigation methods (First, Last, Prior, Absolute,
+	// Relative, RowNumber) for random-access row access.
+	FetchScrollable
+)
@@ -0,0 +1,50 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"bytes"
+	"fmt"
+	"unsafe"
+)
+
+// ORAError represents an error reported by Oracle through an OCI error
+// handle, such as an ORA-xxxxx raised during prepare, execute or fetch.
+type ORAError struct {
+	// Code is the Oracle error number, e.g. 1 for ORA-00001.
+	Code int
+
+	// Message is the Oracle error text associated with Code.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ORAError) Error() string {
+	return fmt.Sprintf("ORA-%05d: %s", e.Code, e.Message)
+}
+
+// ociGetError extracts the error OCI attached to errHandle via OCIErrorGet
+// and returns it as an *ORAError. Call it whenever an OCI call made against
+// errHandle returns OCI_ERROR.
+func ociGetError(errHandle *C.OCIError) error {
+	var code C.sb4
+	buf := make([]byte, 3072)
+	C.OCIErrorGet(
+		unsafe.Pointer(errHandle),
+		1,
+		nil,
+		&code,
+		(*C.OraText)(unsafe.Pointer(&buf[0])),
+		C.ub4(len(buf)),
+		C.OCI_HTYPE_ERROR)
+	if i := bytes.IndexByte(buf, 0); i >= 0 {
+		buf = buf[:i]
+	}
+	return &ORAError{Code: int(code), Message: string(buf)}
+}
@@ -0,0 +1,22 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// ReleaseMode indicates how a cached statement is released back to OCI's
+// statement cache when a Stmt is closed.
+type ReleaseMode uint8
+
+const (
+	// DefaultRelease returns a cached statement to the cache so a subsequent
+	// Prep of the same SQL text can reuse the parsed cursor.
+	DefaultRelease ReleaseMode = iota
+
+	// DropRelease evicts a cached statement from the cache instead of
+	// returning it, forcing the next Prep of the same SQL text to reparse.
+	//
+	// Use DropRelease after DDL or other statements whose cursor should not
+	// be reused.
+	DropRelease
+)
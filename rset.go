@@ -0,0 +1,80 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import "unsafe"
+
+// ErrNotScrollable is returned by Rset's navigation methods (First, Last,
+// Prior, Absolute, Relative, RowNumber) when the Rset was produced by a
+// statement whose StmtConfig.FetchMode is FetchForward.
+var ErrNotScrollable = errNew("rset is not scrollable; set StmtConfig.FetchMode to FetchScrollable")
+
+// Rset represents the result set of a SQL select statement.
+type Rset struct {
+	stmt       *Stmt
+	scrollable bool
+}
+
+// fetch calls OCIStmtFetch2 with the given fetch orientation and offset,
+// positioning rset on the resulting row.
+func (rset *Rset) fetch(orientation C.ub2, offset C.sb4) error {
+	if !rset.scrollable {
+		return ErrNotScrollable
+	}
+	r := C.OCIStmtFetch2(
+		rset.stmt.ocistmt, rset.stmt.ses.ocierr,
+		1, orientation, offset, C.OCI_DEFAULT)
+	if r == C.OCI_ERROR {
+		return ociGetError(rset.stmt.ses.ocierr)
+	}
+	return nil
+}
+
+// First positions rset on the first row of the result set.
+func (rset *Rset) First() error {
+	return rset.fetch(C.OCI_FETCH_FIRST, 0)
+}
+
+// Last positions rset on the last row of the result set.
+func (rset *Rset) Last() error {
+	return rset.fetch(C.OCI_FETCH_LAST, 0)
+}
+
+// Prior positions rset on the row preceding the current row.
+func (rset *Rset) Prior() error {
+	return rset.fetch(C.OCI_FETCH_PRIOR, 0)
+}
+
+// Absolute positions rset on the row at the given 1-based position.
+func (rset *Rset) Absolute(pos int64) error {
+	return rset.fetch(C.OCI_FETCH_ABSOLUTE, C.sb4(pos))
+}
+
+// Relative positions rset delta rows forward (positive) or backward
+// (negative) of the current row.
+func (rset *Rset) Relative(delta int64) error {
+	return rset.fetch(C.OCI_FETCH_RELATIVE, C.sb4(delta))
+}
+
+// RowNumber returns the 1-based position of rset's current row.
+//
+// RowNumber returns ErrNotScrollable if rset is not scrollable.
+func (rset *Rset) RowNumber() (int64, error) {
+	if !rset.scrollable {
+		return 0, ErrNotScrollable
+	}
+	var rowNumber C.ub4
+	r := C.OCIAttrGet(
+		unsafe.Pointer(rset.stmt.ocistmt), C.OCI_HTYPE_STMT,
+		unsafe.Pointer(&rowNumber), nil, C.OCI_ATTR_CURRENT_POSITION, rset.stmt.ses.ocierr)
+	if r == C.OCI_ERROR {
+		return 0, ociGetError(rset.stmt.ses.ocierr)
+	}
+	return int64(rowNumber), nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import "unsafe"
+
+// Ses represents an open Oracle session on which SQL statements are
+// prepared and executed.
+type Ses struct {
+	ocisvcctx *C.OCISvcCtx
+	ocierr    *C.OCIError
+	ocienv    *C.OCIEnv
+
+	// Cfg affects various aspects of ses, including the default StmtConfig
+	// new statements inherit from Prep.
+	Cfg SesCfg
+}
+
+// Prep prepares sql for execution on ses, returning a *Stmt.
+//
+// When Cfg.StmtConfig.StmtCacheSize is non-zero, Prep sets the session's
+// OCI_ATTR_STMTCACHESIZE to it and calls OCIStmtPrepare2 with sql as the
+// cache key, so a later Prep of the same SQL text on ses
+// reuses the parsed cursor from OCI's statement cache instead of
+// reparsing it. When StmtCacheSize is zero, Prep allocates a statement
+// handle with OCIHandleAlloc and prepares it with the uncached
+// OCIStmtPrepare.
+func (ses *Ses) Prep(sql string) (*Stmt, error) {
+	cfg := ses.Cfg.StmtConfig
+	csql := C.CString(sql)
+	defer C.free(unsafe.Pointer(csql))
+	sqlLen := C.ub4(len(sql))
+
+	var ocistmt *C.OCIStmt
+	cached := cfg.StmtCacheSize() > 0
+	if cached {
+		size := C.ub4(cfg.StmtCacheSize())
+		if r := C.OCIAttrSet(
+			unsafe.Pointer(ses.ocisvcctx), C.OCI_HTYPE_SVCCTX,
+			unsafe.Pointer(&size), 0,
+			C.OCI_ATTR_STMTCACHESIZE, ses.ocierr); r == C.OCI_ERROR {
+			return nil, ociGetError(ses.ocierr)
+		}
+		r := C.OCIStmtPrepare2(
+			ses.ocisvcctx,
+			&ocistmt,
+			ses.ocierr,
+			(*C.OraText)(unsafe.Pointer(csql)),
+			sqlLen,
+			nil, 0,
+			C.OCI_NTV_SYNTAX, C.OCI_DEFAULT)
+		if r == C.OCI_ERROR {
+			return nil, ociGetError(ses.ocierr)
+		}
+	} else {
+		var h unsafe.Pointer
+		r := C.OCIHandleAlloc(
+			unsafe.Pointer(ses.ocienv),
+			&h,
+			C.OCI_HTYPE_STMT, 0, nil)
+		if r == C.OCI_ERROR {
+			return nil, ociGetError(ses.ocierr)
+		}
+		ocistmt = (*C.OCIStmt)(h)
+		r = C.OCIStmtPrepare(
+			ocistmt, ses.ocierr,
+			(*C.OraText)(unsafe.Pointer(csql)), sqlLen,
+			C.OCI_NTV_SYNTAX, C.OCI_DEFAULT)
+		if r == C.OCI_ERROR {
+			C.OCIHandleFree(unsafe.Pointer(ocistmt), C.OCI_HTYPE_STMT)
+			return nil, ociGetError(ses.ocierr)
+		}
+	}
+
+	return &Stmt{ses: ses, ocistmt: ocistmt, cfg: cfg, cached: cached}, nil
+}
@@ -0,0 +1,17 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// SesCfg affects various aspects of a session.
+type SesCfg struct {
+	// StmtConfig is the default StmtConfig that statements prepared with
+	// Ses.Prep inherit, including StmtCacheSize and ReleaseMode.
+	StmtConfig StmtConfig
+}
+
+// NewSesCfg returns a SesCfg with default values.
+func NewSesCfg() SesCfg {
+	return SesCfg{StmtConfig: NewStmtConfig()}
+}
@@ -0,0 +1,18 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// SrvCfg affects various aspects of a server connection.
+type SrvCfg struct {
+	// StmtConfig is the default StmtConfig that sessions opened against the
+	// server inherit into their own SesCfg.StmtConfig, including
+	// StmtCacheSize and ReleaseMode.
+	StmtConfig StmtConfig
+}
+
+// NewSrvCfg returns a SrvCfg with default values.
+func NewSrvCfg() SrvCfg {
+	return SrvCfg{StmtConfig: NewStmtConfig()}
+}
@@ -0,0 +1,417 @@
+// Copyright 2014 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// Stmt represents a SQL statement prepared for execution on a Ses.
+type Stmt struct {
+	ses     *Ses
+	ocistmt *C.OCIStmt
+	cfg     StmtConfig
+	cached  bool
+	hints   map[int]BindHint
+}
+
+// Close closes stmt, releasing its OCI resources.
+//
+// When stmt was prepared through OCI's statement cache (StmtConfig.
+// StmtCacheSize non-zero), Close calls OCIStmtRelease with the mode
+// corresponding to StmtConfig.ReleaseMode, returning the cursor to the
+// cache on DefaultRelease or evicting it on DropRelease. Otherwise Close
+// calls OCIHandleFree.
+func (stmt *Stmt) Close() error {
+	if stmt.cached {
+		mode := C.ub4(C.OCI_DEFAULT)
+		if stmt.cfg.ReleaseMode() == DropRelease {
+			mode = C.OCI_STRLS_CACHE_DELETE
+		}
+		r := C.OCIStmtRelease(stmt.ocistmt, stmt.ses.ocierr, nil, 0, mode)
+		if r == C.OCI_ERROR {
+			return ociGetError(stmt.ses.ocierr)
+		}
+		return nil
+	}
+	r := C.OCIHandleFree(unsafe.Pointer(stmt.ocistmt), C.OCI_HTYPE_STMT)
+	if r == C.OCI_ERROR {
+		return ociGetError(stmt.ses.ocierr)
+	}
+	return nil
+}
+
+// Bind sets the BindHint position (1-based) of stmt uses for subsequent
+// ExecHints calls, until replaced by another Bind for that position, overriding the GoColumnType, max size, direction and
+// buffer-ownership that StmtConfig and the argument's Go type would
+// otherwise select for that position.
+func (stmt *Stmt) Bind(position int, hint BindHint) error {
+	if position < 1 {
+		return errNew("Bind parameter 'position' must be greater than zero")
+	}
+	if stmt.hints == nil {
+		stmt.hints = make(map[int]BindHint)
+	}
+	stmt.hints[position] = hint
+	return nil
+}
+
+// ExecHints binds args positionally and executes stmt, applying hints[i] as
+// the BindHint for position i+1 — as if passed to Bind — before falling
+// back to any hint already set via Bind and then to StmtConfig.ByteSlice/
+// StmtConfig.StringPtrBufferSize and the argument's Go type.
+//
+// hints may be shorter than args; positions beyond len(hints) use only a
+// Bind-set hint or the statement-wide defaults.
+//
+// A position whose BindHint.Direction is Out or InOut only reports the
+// value OCI wrote back if the corresponding arg is a pointer (*[]byte or
+// *string); passing the bare []byte or string leaves the argument
+// unmodified after execute.
+func (stmt *Stmt) ExecHints(hints []BindHint, args ...interface{}) error {
+	for i, hint := range hints {
+		if err := stmt.Bind(i+1, hint); err != nil {
+			return err
+		}
+	}
+
+	bufs := make([][]byte, len(args))
+	alens := make([]C.ub2, len(args))
+	for position, arg := range args {
+		buf, err := stmt.bindArg(position+1, arg, &alens[position])
+		if err != nil {
+			return err
+		}
+		bufs[position] = buf
+	}
+
+	r := C.OCIStmtExecute(
+		stmt.ses.ocisvcctx, stmt.ocistmt, stmt.ses.ocierr,
+		1, 0, nil, nil, C.OCI_DEFAULT)
+	if r == C.OCI_ERROR {
+		return ociGetError(stmt.ses.ocierr)
+	}
+
+	for position, arg := range args {
+		hint := stmt.hints[position+1]
+		if hint.Direction == In {
+			continue
+		}
+		switch p := arg.(type) {
+		case *[]byte:
+			*p = bufs[position][:alens[position]]
+		case *string:
+			buf := bufs[position]
+			if i := bytes.IndexByte(buf, 0); i >= 0 {
+				buf = buf[:i]
+			}
+			*p = string(buf)
+		}
+	}
+	return nil
+}
+
+// bindArg binds a single parameter at position (1-based), honoring any
+// BindHint set for it and otherwise falling back to StmtConfig. It returns
+// the buffer passed to OCIBindByPos, which the caller must keep reachable
+// until after OCIStmtExecute runs. alenp receives the actual length OCI
+// read or wrote for the parameter, which ExecHints uses to trim a []byte
+// write-back to the bytes OCI actually returned rather than the whole
+// (possibly larger) bind buffer.
+func (stmt *Stmt) bindArg(position int, arg interface{}, alenp *C.ub2) ([]byte, error) {
+	hint, hasHint := stmt.hints[position]
+
+	var sqlt C.ub2
+	var buf []byte
+
+	switch v := arg.(type) {
+	case []byte:
+		sqlt = stmt.byteSliceSQLT(hint, hasHint)
+		buf = stmt.growForOutput(v, hint, hasHint)
+		*alenp = C.ub2(len(v))
+	case *[]byte:
+		sqlt = stmt.byteSliceSQLT(hint, hasHint)
+		buf = stmt.growForOutput(*v, hint, hasHint)
+		*alenp = C.ub2(len(*v))
+	case string:
+		sqlt = C.SQLT_CHR
+		buf = stmt.stringBuf(v, hint, hasHint)
+		*alenp = C.ub2(len(v))
+	case *string:
+		sqlt = C.SQLT_CHR
+		buf = stmt.stringBuf(*v, hint, hasHint)
+		*alenp = C.ub2(len(*v))
+	default:
+		return nil, errNew(fmt.Sprintf("ExecHints: unsupported argument type at position %d", position))
+	}
+
+	var valuep unsafe.Pointer
+	if len(buf) > 0 {
+		valuep = unsafe.Pointer(&buf[0])
+	}
+
+	var bindp *C.OCIBind
+	r := C.OCIBindByPos(
+		stmt.ocistmt, &bindp, stmt.ses.ocierr,
+		C.ub4(position), valuep, C.sb4(len(buf)), sqlt,
+		nil, alenp, nil, 0, nil, C.OCI_DEFAULT)
+	if r == C.OCI_ERROR {
+		return nil, ociGetError(stmt.ses.ocierr)
+	}
+	return buf, nil
+}
+
+// byteSliceSQLT returns the OCI external datatype code a []byte/*[]byte
+// parameter binds as, per hint.GctType or, absent a hint, StmtConfig.
+// ByteSlice.
+func (stmt *Stmt) byteSliceSQLT(hint BindHint, hasHint bool) C.ub2 {
+	gct := stmt.cfg.ByteSlice()
+	if hasHint && hint.GctType != 0 {
+		gct = hint.GctType
+	}
+	if gct == Bits {
+		return C.SQLT_BIN
+	}
+	return C.SQLT_NUM
+}
+
+// stringBuf returns a buffer holding v, at least as large as hint.MaxSize
+// (or StmtConfig.StringPtrBufferSize absent a hint) so the buffer never
+// silently truncates v.
+func (stmt *Stmt) stringBuf(v string, hint BindHint, hasHint bool) []byte {
+	maxSize := stmt.cfg.StringPtrBufferSize()
+	if hasHint && hint.MaxSize > 0 {
+		maxSize = hint.MaxSize
+	}
+	if len(v) > maxSize {
+		maxSize = len(v)
+	}
+	buf := make([]byte, maxSize)
+	copy(buf, v)
+	return buf
+}
+
+// growForOutput grows buf to hint.MaxSize when hint.Direction is Out or
+// InOut, so OCI has room to write a returned value into it, unless
+// hint.ZeroCopy requests binding directly into the caller's buffer.
+func (stmt *Stmt) growForOutput(buf []byte, hint BindHint, hasHint bool) []byte {
+	if !hasHint || hint.ZeroCopy || hint.Direction == In || hint.MaxSize <= len(buf) {
+		return buf
+	}
+	grown := make([]byte, hint.MaxSize)
+	copy(grown, buf)
+	return grown
+}
+
+// Query executes stmt as a SQL select and returns the resulting *Rset.
+//
+// When StmtConfig.FetchMode is FetchScrollable, Query executes with
+// OCI_STMT_SCROLLABLE_READONLY so the returned Rset supports First, Last,
+// Prior, Absolute, Relative and RowNumber; otherwise the Rset is
+// forward-only and those methods return ErrNotScrollable.
+func (stmt *Stmt) Query() (*Rset, error) {
+	if err := stmt.wireLOBPrefetch(); err != nil {
+		return nil, err
+	}
+
+	scrollable := stmt.cfg.FetchMode() == FetchScrollable
+	mode := C.ub4(C.OCI_DEFAULT)
+	if scrollable {
+		mode = C.OCI_STMT_SCROLLABLE_READONLY
+	}
+	r := C.OCIStmtExecute(
+		stmt.ses.ocisvcctx, stmt.ocistmt, stmt.ses.ocierr,
+		0, 0, nil, nil, mode)
+	if r == C.OCI_ERROR {
+		return nil, ociGetError(stmt.ses.ocierr)
+	}
+	return &Rset{stmt: stmt, scrollable: scrollable}, nil
+}
+
+// wireLOBPrefetch sets the statement-level LOB prefetch attributes that
+// StmtConfig.PrefetchLOBData, LobPrefetchSize and LobPrefetchLength
+// configure, leaving PrefetchRowCount/PrefetchMemorySize to continue
+// governing scalar-only queries.
+func (stmt *Stmt) wireLOBPrefetch() error {
+	if !stmt.cfg.PrefetchLOBData() {
+		return nil
+	}
+
+	size := C.ub4(stmt.cfg.LobPrefetchSize())
+	if r := C.OCIAttrSet(
+		unsafe.Pointer(stmt.ocistmt), C.OCI_HTYPE_STMT,
+		unsafe.Pointer(&size), 0,
+		C.OCI_ATTR_DEFAULT_LOBPREFETCH_SIZE, stmt.ses.ocierr); r == C.OCI_ERROR {
+		return ociGetError(stmt.ses.ocierr)
+	}
+
+	var length C.ub4
+	if stmt.cfg.LobPrefetchLength() {
+		length = 1
+	}
+	if r := C.OCIAttrSet(
+		unsafe.Pointer(stmt.ocistmt), C.OCI_HTYPE_STMT,
+		unsafe.Pointer(&length), 0,
+		C.OCI_ATTR_LOBPREFETCH_LENGTH, stmt.ses.ocierr); r == C.OCI_ERROR {
+		return ociGetError(stmt.ses.ocierr)
+	}
+	return nil
+}
+
+// SetPrefetchMemorySize overrides stmt's prefetch memory size, in bytes,
+// directly on its OCI statement handle via OCI_ATTR_PREFETCH_MEMORY, and
+// records it in stmt's own copy of StmtConfig. The session's
+// Cfg.StmtConfig is not modified, so a single expensive query can be tuned
+// without cloning the session-wide config.
+func (stmt *Stmt) SetPrefetchMemorySize(prefetchMemorySize uint32) error {
+	size := C.ub4(prefetchMemorySize)
+	if r := C.OCIAttrSet(
+		unsafe.Pointer(stmt.ocistmt), C.OCI_HTYPE_STMT,
+		unsafe.Pointer(&size), 0,
+		C.OCI_ATTR_PREFETCH_MEMORY, stmt.ses.ocierr); r == C.OCI_ERROR {
+		return ociGetError(stmt.ses.ocierr)
+	}
+	stmt.cfg.prefetchMemorySize = prefetchMemorySize
+	return nil
+}
+
+// ExecBatch executes stmt once against rowCount rows using OCI array binds:
+// every element of args is bound as an array via OCIBindByPos and
+// OCIStmtExecute is called with iters equal to the chunk's row count.
+//
+// Every args[i] must be a slice of length rowCount; ExecBatch returns an
+// error otherwise. When StmtConfig.BatchSize is non-zero and smaller than
+// rowCount, ExecBatch chunks the call into multiple OCIStmtExecute calls of
+// at most BatchSize rows each.
+//
+// Per-row failures reported by Oracle's OCI_BATCH_ERRORS mode are collected
+// into a *BatchError rather than aborting the whole call, so a partial
+// batch does not lose diagnostics.
+func (stmt *Stmt) ExecBatch(rowCount uint32, args ...interface{}) error {
+	for i, arg := range args {
+		v := reflect.ValueOf(arg)
+		if v.Kind() != reflect.Slice {
+			return errNew(fmt.Sprintf("ExecBatch parameter args[%d] must be a slice", i))
+		}
+		if uint32(v.Len()) != rowCount {
+			return errNew(fmt.Sprintf("ExecBatch parameter args[%d] has length %d, want %d", i, v.Len(), rowCount))
+		}
+	}
+
+	chunk := rowCount
+	if bs := stmt.cfg.BatchSize(); bs > 0 && bs < rowCount {
+		chunk = bs
+	}
+
+	var batchErr BatchError
+	for offset := uint32(0); offset < rowCount; offset += chunk {
+		n := chunk
+		if offset+n > rowCount {
+			n = rowCount - offset
+		}
+		if err := stmt.execArray(offset, n, args); err != nil {
+			return err
+		}
+		batchErr.Rows = append(batchErr.Rows, stmt.batchRowErrors(offset)...)
+	}
+	if len(batchErr.Rows) > 0 {
+		return &batchErr
+	}
+	return nil
+}
+
+// execArray binds the n rows of args starting at offset as OCI arrays and
+// calls OCIStmtExecute with iters=n under OCI_BATCH_ERRORS so Oracle
+// continues past per-row failures instead of aborting the chunk.
+func (stmt *Stmt) execArray(offset, n uint32, args []interface{}) error {
+	for position, arg := range args {
+		v := reflect.ValueOf(arg)
+		base := unsafe.Pointer(v.Index(int(offset)).UnsafeAddr())
+		elemSize := C.sb4(v.Type().Elem().Size())
+
+		var bindp *C.OCIBind
+		r := C.OCIBindByPos(
+			stmt.ocistmt, &bindp, stmt.ses.ocierr,
+			C.ub4(position+1),
+			base, elemSize, oraSQLT(v.Type().Elem()),
+			nil, nil, nil, 0, nil,
+			C.OCI_DEFAULT)
+		if r == C.OCI_ERROR {
+			return ociGetError(stmt.ses.ocierr)
+		}
+	}
+
+	r := C.OCIStmtExecute(
+		stmt.ses.ocisvcctx, stmt.ocistmt, stmt.ses.ocierr,
+		C.ub4(n), 0, nil, nil, C.OCI_BATCH_ERRORS)
+	if r == C.OCI_ERROR {
+		return ociGetError(stmt.ses.ocierr)
+	}
+	return nil
+}
+
+// batchRowErrors reads the per-row failures OCI recorded for the chunk that
+// just executed, via OCI_ATTR_NUM_DML_ERRORS and the per-row error handles
+// OCIParamGet returns from the session's error handle, reading each one's
+// OCI_ATTR_DML_ROW_OFFSET and error, and translates each into a
+// BatchErrorRow indexed against the full ExecBatch row count.
+func (stmt *Stmt) batchRowErrors(offset uint32) []BatchErrorRow {
+	var numErrs C.ub4
+	C.OCIAttrGet(
+		unsafe.Pointer(stmt.ocistmt), C.OCI_HTYPE_STMT,
+		unsafe.Pointer(&numErrs), nil, C.OCI_ATTR_NUM_DML_ERRORS, stmt.ses.ocierr)
+	if numErrs == 0 {
+		return nil
+	}
+
+	rows := make([]BatchErrorRow, 0, numErrs)
+	for i := C.ub4(0); i < numErrs; i++ {
+		var errh unsafe.Pointer
+		if r := C.OCIHandleAlloc(
+			unsafe.Pointer(stmt.ses.ocienv), &errh,
+			C.OCI_HTYPE_ERROR, 0, nil); r == C.OCI_ERROR {
+			continue
+		}
+		r := C.OCIParamGet(
+			unsafe.Pointer(stmt.ses.ocierr), C.OCI_HTYPE_ERROR, stmt.ses.ocierr,
+			&errh, i)
+		if r == C.OCI_ERROR {
+			C.OCIHandleFree(errh, C.OCI_HTYPE_ERROR)
+			continue
+		}
+
+		var rowOffset C.ub4
+		C.OCIAttrGet(
+			errh, C.OCI_HTYPE_ERROR,
+			unsafe.Pointer(&rowOffset), nil, C.OCI_ATTR_DML_ROW_OFFSET, stmt.ses.ocierr)
+
+		rows = append(rows, BatchErrorRow{
+			RowIndex: int(offset) + int(rowOffset),
+			ORAError: ociGetError((*C.OCIError)(errh)).(*ORAError),
+		})
+		C.OCIHandleFree(errh, C.OCI_HTYPE_ERROR)
+	}
+	return rows
+}
+
+// oraSQLT returns the OCI external datatype code used to bind a Go array
+// element type in ExecBatch.
+func oraSQLT(t reflect.Type) C.ub2 {
+	switch t.Kind() {
+	case reflect.String:
+		return C.SQLT_CHR
+	case reflect.Float32, reflect.Float64:
+		return C.SQLT_FLT
+	default:
+		return C.SQLT_INT
+	}
+}
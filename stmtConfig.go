@@ -16,6 +16,13 @@ type StmtConfig struct {
 	lobBufferSize       int
 	stringPtrBufferSize int
 	byteSlice           GoColumnType
+	stmtCacheSize       uint32
+	releaseMode         ReleaseMode
+	batchSize           uint32
+	fetchMode           FetchMode
+	lobPrefetchSize     uint32
+	lobPrefetchLength   bool
+	prefetchLOBData     bool
 
 	// IsAutoCommitting determines whether DML statements are automatically
 	// committed.
@@ -56,6 +63,13 @@ func (c *StmtConfig) Reset() {
 	c.longRawBufferSize = 1 << 24  // 16,777,216
 	c.lobBufferSize = 1 << 24      // 16,777,216
 	c.stringPtrBufferSize = 4000
+	c.stmtCacheSize = 0
+	c.releaseMode = DefaultRelease
+	c.batchSize = 0
+	c.fetchMode = FetchForward
+	c.lobPrefetchSize = 0
+	c.lobPrefetchLength = false
+	c.prefetchLOBData = false
 
 	c.IsAutoCommitting = true
 	c.FalseRune = '0'
@@ -228,4 +242,130 @@ func (c *StmtConfig) SetByteSlice(gct GoColumnType) (err error) {
 // if the destination column is NUMBER, BINARY_DOUBLE, BINARY_FLOAT or FLOAT.
 func (c *StmtConfig) ByteSlice() GoColumnType {
 	return c.byteSlice
+}
+
+// SetStmtCacheSize sets the number of statements OCI's statement cache holds
+// for the session a Stmt belongs to.
+//
+// When StmtCacheSize is non-zero, Ses.Prep prepares through OCI's statement
+// cache keyed on the SQL text, via OCIStmtPrepare2, so a second Prep of the
+// same SQL on the same session reuses the parsed cursor. When zero, Prep
+// falls back to the uncached OCIHandleAlloc/OCIStmtPrepare path.
+func (c *StmtConfig) SetStmtCacheSize(stmtCacheSize uint32) error {
+	c.stmtCacheSize = stmtCacheSize
+	return nil
+}
+
+// StmtCacheSize returns the number of statements OCI's statement cache holds
+// for the session a Stmt belongs to.
+//
+// The default is 0, meaning statements are not cached.
+func (c *StmtConfig) StmtCacheSize() uint32 {
+	return c.stmtCacheSize
+}
+
+// SetReleaseMode sets the ReleaseMode Stmt.Close passes to OCIStmtRelease
+// when releasing a cached statement.
+func (c *StmtConfig) SetReleaseMode(releaseMode ReleaseMode) error {
+	c.releaseMode = releaseMode
+	return nil
+}
+
+// ReleaseMode returns the ReleaseMode Stmt.Close passes to OCIStmtRelease
+// when releasing a cached statement.
+//
+// The default is DefaultRelease.
+//
+// ReleaseMode only takes effect when StmtCacheSize is non-zero.
+func (c *StmtConfig) ReleaseMode() ReleaseMode {
+	return c.releaseMode
+}
+
+// SetBatchSize sets the number of rows Stmt.ExecBatch sends to the server in
+// a single OCIStmtExecute call when the caller's row count exceeds it.
+//
+// Returns an error if batchSize is zero.
+func (c *StmtConfig) SetBatchSize(batchSize uint32) error {
+	if batchSize < 1 {
+		return errNew("SetBatchSize parameter 'batchSize' must be greater than zero")
+	}
+	c.batchSize = batchSize
+	return nil
+}
+
+// BatchSize returns the number of rows Stmt.ExecBatch sends to the server in
+// a single OCIStmtExecute call when the caller's row count exceeds it.
+//
+// The default is 0, meaning Stmt.ExecBatch sends the entire row count in one
+// call.
+func (c *StmtConfig) BatchSize() uint32 {
+	return c.batchSize
+}
+
+// SetFetchMode sets whether Stmt.Query executes a SQL select statement for
+// forward-only or scrollable row access.
+func (c *StmtConfig) SetFetchMode(fetchMode FetchMode) error {
+	c.fetchMode = fetchMode
+	return nil
+}
+
+// FetchMode returns whether Stmt.Query executes a SQL select statement for
+// forward-only or scrollable row access.
+//
+// The default is FetchForward.
+func (c *StmtConfig) FetchMode() FetchMode {
+	return c.fetchMode
+}
+
+// SetPrefetchLOBData sets whether LOB column values are prefetched inline
+// with the row instead of fetched lazily on access.
+//
+// PrefetchLOBData, LobPrefetchSize and LobPrefetchLength only affect selects
+// whose columns include a LOB define; PrefetchRowCount and
+// PrefetchMemorySize continue to govern scalar-only queries unchanged.
+func (c *StmtConfig) SetPrefetchLOBData(prefetchLOBData bool) error {
+	c.prefetchLOBData = prefetchLOBData
+	return nil
+}
+
+// PrefetchLOBData returns whether LOB column values are prefetched inline
+// with the row instead of fetched lazily on access.
+//
+// The default is false.
+func (c *StmtConfig) PrefetchLOBData() bool {
+	return c.prefetchLOBData
+}
+
+// SetLobPrefetchSize sets the number of bytes of LOB data Stmt.Query
+// prefetches inline per row, via OCI_ATTR_DEFAULT_LOBPREFETCH_SIZE, when
+// PrefetchLOBData is true.
+func (c *StmtConfig) SetLobPrefetchSize(lobPrefetchSize uint32) error {
+	c.lobPrefetchSize = lobPrefetchSize
+	return nil
+}
+
+// LobPrefetchSize returns the number of bytes of LOB data Stmt.Query
+// prefetches inline per row, via OCI_ATTR_DEFAULT_LOBPREFETCH_SIZE, when
+// PrefetchLOBData is true.
+//
+// The default is 0.
+func (c *StmtConfig) LobPrefetchSize() uint32 {
+	return c.lobPrefetchSize
+}
+
+// SetLobPrefetchLength sets whether Stmt.Query also prefetches each LOB's
+// length inline, via OCI_ATTR_LOBPREFETCH_LENGTH, when PrefetchLOBData is
+// true, so a later length lookup does not require a round trip.
+func (c *StmtConfig) SetLobPrefetchLength(lobPrefetchLength bool) error {
+	c.lobPrefetchLength = lobPrefetchLength
+	return nil
+}
+
+// LobPrefetchLength returns whether Stmt.Query also prefetches each LOB's
+// length inline, via OCI_ATTR_LOBPREFETCH_LENGTH, when PrefetchLOBData is
+// true.
+//
+// The default is false.
+func (c *StmtConfig) LobPrefetchLength() bool {
+	return c.lobPrefetchLength
 }
\ No newline at end of file